@@ -9,6 +9,7 @@ package syscall
 import (
 	"io"
 	"sync"
+	"sync/atomic"
 	"syscall/js"
 )
 
@@ -31,17 +32,60 @@ var (
 )
 
 type jsFile struct {
-	path    string
-	entries []string
-	pos     int64
-	seeked  bool
+	path   string
+	pos    int64
+	seeked bool
+
+	// realFD is the Node fd backing this file. It is only meaningful when
+	// pipe is nil; for pipe ends there is no underlying Node fd.
+	realFD int
+
+	// pipe is non-nil when this fd is an in-process pipe end created by
+	// Pipe, in which case Read/Write/Close/Fstat bypass fsCall entirely and
+	// operate on the buffer directly. pipeWrite says which end this fd is.
+	pipe      *pipeBuffer
+	pipeWrite bool
+
+	// refs is shared by every fd table entry pointing at this *jsFile (see
+	// Dup and Dup2), so Close only tears the file down once the last
+	// reference drops.
+	refs *int32
+
+	// Directory entry paging. isDir is set in Open once fstat reports a
+	// directory; everything else here is left zero until the first
+	// ReadDirent call, so opening a directory just to Stat it or peek at a
+	// handful of names doesn't pay for listing it up front. See
+	// jsFile.fillDirWindow.
+	isDir       bool
+	dirOpened   bool     // fillDirWindow has run at least once
+	dirFallback bool     // opendirSync unavailable; using one-shot readdirSync
+	dirDone     bool     // no more entries left to page in
+	dirHandle   js.Value // opendirSync() Dir, valid when !dirFallback
+	dirWindow   []string // entries ready for ReadDirent
+}
+
+func newRefs() *int32 {
+	n := int32(1)
+	return &n
 }
 
 var filesMu sync.Mutex
 var files = map[int]*jsFile{
-	0: &jsFile{},
-	1: &jsFile{},
-	2: &jsFile{},
+	0: &jsFile{realFD: 0, refs: newRefs()},
+	1: &jsFile{realFD: 1, refs: newRefs()},
+	2: &jsFile{realFD: 2, refs: newRefs()},
+}
+
+// nextSyntheticFD hands out fd numbers for Dup, Dup2, and Pipe. These are
+// always negative so they can never collide with the non-negative fds that
+// Node's fs module assigns in Open. filesMu must be held when reading
+// or decrementing it.
+var nextSyntheticFD int32 = -1
+
+func allocFD() int {
+	fd := int(nextSyntheticFD)
+	nextSyntheticFD--
+	return fd
 }
 
 func fdToFile(fd int) (*jsFile, error) {
@@ -54,6 +98,40 @@ func fdToFile(fd int) (*jsFile, error) {
 	return f, nil
 }
 
+// releaseFD drops fd's table entry and decrements its refcount, returning
+// the underlying *jsFile if this was the last reference so the caller can
+// tear it down outside of filesMu. It reports no file if fd is unknown or
+// other references remain. filesMu must be held by the caller.
+func releaseFD(fd int) *jsFile {
+	f, ok := files[fd]
+	if !ok {
+		return nil
+	}
+	delete(files, fd)
+	if atomic.AddInt32(f.refs, -1) > 0 {
+		return nil
+	}
+	return f
+}
+
+// teardown releases the OS-level (or in-process) resource backing f. It
+// must only be called once the last fd referencing f has been released.
+func teardown(f *jsFile) error {
+	if f.pipe != nil {
+		if f.pipeWrite {
+			f.pipe.closeWrite()
+		} else {
+			f.pipe.closeRead()
+		}
+		return nil
+	}
+	if f.dirOpened && !f.dirFallback && !f.dirDone {
+		jsCall(f.dirHandle, "closeSync")
+	}
+	_, err := fsCall("closeSync", f.realFD)
+	return err
+}
+
 func Open(path string, openmode int, perm uint32) (int, error) {
 	if err := checkPath(path); err != nil {
 		return 0, err
@@ -85,27 +163,22 @@ func Open(path string, openmode int, perm uint32) (int, error) {
 		flags |= nodeSYNC
 	}
 
-	jsFD, err := fsCall("openSync", path, flags, perm)
+	jsFD, err := fsCallAsync("open", path, flags, perm)
 	if err != nil {
 		return 0, err
 	}
 	fd := jsFD.Int()
 
-	var entries []string
-	if stat, err := fsCall("fstatSync", fd); err == nil && stat.Call("isDirectory").Bool() {
-		dir, err := fsCall("readdirSync", path)
-		if err != nil {
-			return 0, err
-		}
-		entries = make([]string, dir.Length())
-		for i := range entries {
-			entries[i] = dir.Index(i).String()
-		}
+	isDir := false
+	if stat, err := fsCallAsync("fstat", fd); err == nil {
+		isDir = stat.Call("isDirectory").Bool()
 	}
 
 	f := &jsFile{
-		path:    path,
-		entries: entries,
+		path:   path,
+		realFD: fd,
+		refs:   newRefs(),
+		isDir:  isDir,
 	}
 	filesMu.Lock()
 	files[fd] = f
@@ -115,10 +188,20 @@ func Open(path string, openmode int, perm uint32) (int, error) {
 
 func Close(fd int) error {
 	filesMu.Lock()
-	delete(files, fd)
+	if w, ok := watchers[fd]; ok {
+		delete(watchers, fd)
+		filesMu.Unlock()
+		close(w.done)
+		w.jsWatcher.Call("close")
+		w.callback.Release()
+		return nil
+	}
+	f := releaseFD(fd)
 	filesMu.Unlock()
-	_, err := fsCall("closeSync", fd)
-	return err
+	if f == nil {
+		return nil
+	}
+	return teardown(f)
 }
 
 func CloseOnExec(fd int) {
@@ -129,7 +212,7 @@ func Mkdir(path string, perm uint32) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	_, err := fsCall("mkdirSync", path, perm)
+	_, err := fsCallAsync("mkdir", path, perm)
 	return err
 }
 
@@ -138,13 +221,25 @@ func ReadDirent(fd int, buf []byte) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	if f.entries == nil {
+	if !f.isDir {
 		return 0, EINVAL
 	}
 
 	n := 0
-	for len(f.entries) > 0 {
-		entry := f.entries[0]
+	for {
+		if len(f.dirWindow) == 0 {
+			if f.dirDone {
+				break
+			}
+			if err := f.fillDirWindow(len(buf)); err != nil {
+				return n, err
+			}
+			if len(f.dirWindow) == 0 {
+				break
+			}
+		}
+
+		entry := f.dirWindow[0]
 		l := 2 + len(entry)
 		if l > len(buf) {
 			break
@@ -154,12 +249,87 @@ func ReadDirent(fd int, buf []byte) (int, error) {
 		copy(buf[2:], entry)
 		buf = buf[l:]
 		n += l
-		f.entries = f.entries[1:]
+		f.dirWindow = f.dirWindow[1:]
 	}
 
 	return n, nil
 }
 
+// fillDirWindow pages more names into f.dirWindow. It prefers Node's
+// streaming fs.opendirSync/dir.readSync() API, pulling one Dirent per call
+// (that's all readSync returns — there is no batched form) until it has
+// gathered roughly as many entries as bufLen (the caller's ReadDirent
+// buffer) can hold or the directory is exhausted, so a directory with tens
+// of thousands of entries doesn't have its entire listing materialized and
+// held for the lifetime of the fd. It falls back to a single readdirSync
+// call when opendirSync isn't available.
+func (f *jsFile) fillDirWindow(bufLen int) error {
+	if !f.dirOpened {
+		f.dirOpened = true
+		if jsFS.Get("opendirSync").Type() != js.TypeFunction {
+			f.dirFallback = true
+		} else {
+			dir, err := fsCall("opendirSync", f.path)
+			if err != nil {
+				// A real failure (EACCES, EMFILE, ...), not merely an
+				// absent API: surface it instead of masking it as a
+				// fallback to readdirSync.
+				f.dirDone = true
+				return err
+			}
+			if dir.Get("readSync").Type() != js.TypeFunction {
+				jsCall(dir, "closeSync")
+				f.dirFallback = true
+			} else {
+				f.dirHandle = dir
+			}
+		}
+	}
+
+	if f.dirFallback {
+		return f.fillDirWindowFallback()
+	}
+
+	// Roughly estimate how many dirents bufLen can hold (2 bytes of length
+	// prefix plus a handful of name bytes each); always ask for at least
+	// one so forward progress doesn't stall on a tiny buffer.
+	want := bufLen / 8
+	if want < 1 {
+		want = 1
+	}
+
+	for i := 0; i < want; i++ {
+		entry, err := jsCall(f.dirHandle, "readSync")
+		if err != nil {
+			return err
+		}
+		if entry.Type() == js.TypeNull {
+			// The directory is exhausted.
+			f.dirDone = true
+			_, err := jsCall(f.dirHandle, "closeSync")
+			return err
+		}
+		f.dirWindow = append(f.dirWindow, entry.Get("name").String())
+	}
+	return nil
+}
+
+// fillDirWindowFallback is the one-shot readdirSync path used when the
+// runtime's "fs" object has no opendirSync.
+func (f *jsFile) fillDirWindowFallback() error {
+	dir, err := fsCall("readdirSync", f.path)
+	if err != nil {
+		f.dirDone = true
+		return err
+	}
+	f.dirWindow = make([]string, dir.Length())
+	for i := range f.dirWindow {
+		f.dirWindow[i] = dir.Index(i).String()
+	}
+	f.dirDone = true
+	return nil
+}
+
 func setStat(st *Stat_t, jsSt js.Value) {
 	st.Dev = int64(jsSt.Get("dev").Int())
 	st.Ino = uint64(jsSt.Get("ino").Int())
@@ -186,7 +356,7 @@ func Stat(path string, st *Stat_t) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	jsSt, err := fsCall("statSync", path)
+	jsSt, err := fsCallAsync("stat", path)
 	if err != nil {
 		return err
 	}
@@ -198,7 +368,7 @@ func Lstat(path string, st *Stat_t) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	jsSt, err := fsCall("lstatSync", path)
+	jsSt, err := fsCallAsync("lstat", path)
 	if err != nil {
 		return err
 	}
@@ -207,7 +377,15 @@ func Lstat(path string, st *Stat_t) error {
 }
 
 func Fstat(fd int, st *Stat_t) error {
-	jsSt, err := fsCall("fstatSync", fd)
+	f, err := fdToFile(fd)
+	if err != nil {
+		return err
+	}
+	if f.pipe != nil {
+		*st = Stat_t{Mode: 0010000 | 0600} // S_IFIFO, no Node fd backs a pipe end
+		return nil
+	}
+	jsSt, err := fsCallAsync("fstat", f.realFD)
 	if err != nil {
 		return err
 	}
@@ -219,7 +397,7 @@ func Unlink(path string) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	_, err := fsCall("unlinkSync", path)
+	_, err := fsCallAsync("unlink", path)
 	return err
 }
 
@@ -227,7 +405,7 @@ func Rmdir(path string) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	_, err := fsCall("rmdirSync", path)
+	_, err := fsCallAsync("rmdir", path)
 	return err
 }
 
@@ -235,12 +413,19 @@ func Chmod(path string, mode uint32) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	_, err := fsCall("chmodSync", path, mode)
+	_, err := fsCallAsync("chmod", path, mode)
 	return err
 }
 
 func Fchmod(fd int, mode uint32) error {
-	_, err := fsCall("fchmodSync", fd, mode)
+	f, err := fdToFile(fd)
+	if err != nil {
+		return err
+	}
+	if f.pipe != nil {
+		return ENOSYS
+	}
+	_, err = fsCallAsync("fchmod", f.realFD, mode)
 	return err
 }
 
@@ -271,7 +456,7 @@ func UtimesNano(path string, ts []Timespec) error {
 	}
 	atime := ts[0].Sec
 	mtime := ts[1].Sec
-	_, err := fsCall("utimesSync", path, atime, mtime)
+	_, err := fsCallAsync("utimes", path, atime, mtime)
 	return err
 }
 
@@ -282,7 +467,7 @@ func Rename(from, to string) error {
 	if err := checkPath(to); err != nil {
 		return err
 	}
-	_, err := fsCall("renameSync", from, to)
+	_, err := fsCallAsync("rename", from, to)
 	return err
 }
 
@@ -290,12 +475,19 @@ func Truncate(path string, length int64) error {
 	if err := checkPath(path); err != nil {
 		return err
 	}
-	_, err := fsCall("truncateSync", path, length)
+	_, err := fsCallAsync("truncate", path, length)
 	return err
 }
 
 func Ftruncate(fd int, length int64) error {
-	_, err := fsCall("ftruncateSync", fd, length)
+	f, err := fdToFile(fd)
+	if err != nil {
+		return err
+	}
+	if f.pipe != nil {
+		return ENOSYS
+	}
+	_, err = fsCall("ftruncateSync", f.realFD, length)
 	return err
 }
 
@@ -327,7 +519,7 @@ func Readlink(path string, buf []byte) (n int, err error) {
 	if err := checkPath(path); err != nil {
 		return 0, err
 	}
-	dst, err := fsCall("readlinkSync", path)
+	dst, err := fsCallAsync("readlink", path)
 	if err != nil {
 		return 0, err
 	}
@@ -342,7 +534,7 @@ func Link(path, link string) error {
 	if err := checkPath(link); err != nil {
 		return err
 	}
-	_, err := fsCall("linkSync", path, link)
+	_, err := fsCallAsync("link", path, link)
 	return err
 }
 
@@ -353,12 +545,19 @@ func Symlink(path, link string) error {
 	if err := checkPath(link); err != nil {
 		return err
 	}
-	_, err := fsCall("symlinkSync", path, link)
+	_, err := fsCallAsync("symlink", path, link)
 	return err
 }
 
 func Fsync(fd int) error {
-	_, err := fsCall("fsyncSync", fd)
+	f, err := fdToFile(fd)
+	if err != nil {
+		return err
+	}
+	if f.pipe != nil {
+		return nil
+	}
+	_, err = fsCallAsync("fsync", f.realFD)
 	return err
 }
 
@@ -368,13 +567,24 @@ func Read(fd int, b []byte) (int, error) {
 		return 0, err
 	}
 
+	if f.pipe != nil {
+		if f.pipeWrite {
+			return 0, EBADF
+		}
+		return f.pipe.Read(b)
+	}
+
 	if f.seeked {
 		n, err := Pread(fd, b, f.pos)
 		f.pos += int64(n)
 		return n, err
 	}
 
-	n, err := fsCall("readSync", fd, b, 0, len(b))
+	// fs.read's callback-style signature is (fd, buffer, offset, length,
+	// position, callback); position must be passed explicitly (unlike
+	// readSync, where it's optional) or Node binds the injected callback to
+	// the position parameter and throws before ever calling it.
+	n, err := fsCallAsync("read", f.realFD, b, 0, len(b), nil)
 	if err != nil {
 		return 0, err
 	}
@@ -389,13 +599,22 @@ func Write(fd int, b []byte) (int, error) {
 		return 0, err
 	}
 
+	if f.pipe != nil {
+		if !f.pipeWrite {
+			return 0, EBADF
+		}
+		return f.pipe.Write(b)
+	}
+
 	if f.seeked {
 		n, err := Pwrite(fd, b, f.pos)
 		f.pos += int64(n)
 		return n, err
 	}
 
-	n, err := fsCall("writeSync", fd, b, 0, len(b))
+	// As in Read, pass position explicitly rather than relying on Node
+	// scavenging the injected callback out of the trailing arguments.
+	n, err := fsCallAsync("write", f.realFD, b, 0, len(b), nil)
 	if err != nil {
 		return 0, err
 	}
@@ -405,7 +624,14 @@ func Write(fd int, b []byte) (int, error) {
 }
 
 func Pread(fd int, b []byte, offset int64) (int, error) {
-	n, err := fsCall("readSync", fd, b, 0, len(b), offset)
+	f, err := fdToFile(fd)
+	if err != nil {
+		return 0, err
+	}
+	if f.pipe != nil {
+		return 0, ESPIPE
+	}
+	n, err := fsCallAsync("read", f.realFD, b, 0, len(b), offset)
 	if err != nil {
 		return 0, err
 	}
@@ -413,7 +639,14 @@ func Pread(fd int, b []byte, offset int64) (int, error) {
 }
 
 func Pwrite(fd int, b []byte, offset int64) (int, error) {
-	n, err := fsCall("writeSync", fd, b, 0, len(b), offset)
+	f, err := fdToFile(fd)
+	if err != nil {
+		return 0, err
+	}
+	if f.pipe != nil {
+		return 0, ESPIPE
+	}
+	n, err := fsCallAsync("write", f.realFD, b, 0, len(b), offset)
 	if err != nil {
 		return 0, err
 	}
@@ -425,6 +658,9 @@ func Seek(fd int, offset int64, whence int) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if f.pipe != nil {
+		return 0, ESPIPE
+	}
 
 	var newPos int64
 	switch whence {
@@ -452,20 +688,255 @@ func Seek(fd int, offset int64, whence int) (int64, error) {
 }
 
 func Dup(fd int) (int, error) {
-	return 0, ENOSYS
+	filesMu.Lock()
+	defer filesMu.Unlock()
+
+	f, ok := files[fd]
+	if !ok {
+		return 0, EBADF
+	}
+	newfd := allocFD()
+	atomic.AddInt32(f.refs, 1)
+	files[newfd] = f
+	return newfd, nil
 }
 
 func Dup2(fd, newfd int) error {
-	return ENOSYS
+	filesMu.Lock()
+
+	f, ok := files[fd]
+	if !ok {
+		filesMu.Unlock()
+		return EBADF
+	}
+	if fd == newfd {
+		filesMu.Unlock()
+		return nil
+	}
+	old := releaseFD(newfd)
+	atomic.AddInt32(f.refs, 1)
+	files[newfd] = f
+
+	filesMu.Unlock()
+	if old != nil {
+		return teardown(old)
+	}
+	return nil
 }
 
+// Pipe creates an in-process pipe: fd[0] is the read end and fd[1] is the
+// write end. Unlike the other functions in this file, it has no Node
+// syscall to route through: the fd table backs each end with a shared
+// pipeBuffer and the usual Read/Write/Close/Fstat calls operate on that
+// buffer directly instead of calling out to fsCall.
 func Pipe(fd []int) error {
-	return ENOSYS
+	if len(fd) != 2 {
+		return EINVAL
+	}
+
+	buf := newPipeBuffer()
+	filesMu.Lock()
+	rfd := allocFD()
+	files[rfd] = &jsFile{pipe: buf, refs: newRefs()}
+	wfd := allocFD()
+	files[wfd] = &jsFile{pipe: buf, pipeWrite: true, refs: newRefs()}
+	filesMu.Unlock()
+
+	fd[0] = rfd
+	fd[1] = wfd
+	return nil
+}
+
+// pipeBufferCap bounds a pipeBuffer's queued bytes, matching the typical
+// Linux pipe buffer size so a fast writer blocks instead of growing memory
+// without limit when the reader can't keep up.
+const pipeBufferCap = 64 * 1024
+
+// pipeBuffer is a bounded, blocking byte queue used to back the fds
+// returned by Pipe. Reads block until data is available or the write end is
+// closed (returning io.EOF once drained); writes block while the queue is
+// full and return EPIPE once the read end has closed.
+type pipeBuffer struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	buf   []byte
+	rdone bool
+	wdone bool
 }
 
-func fsCall(name string, args ...interface{}) (res js.Value, err error) {
+func newPipeBuffer() *pipeBuffer {
+	b := &pipeBuffer{}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+func (b *pipeBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for len(b.buf) == 0 && !b.wdone {
+		b.cond.Wait()
+	}
+	if len(b.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	b.cond.Broadcast()
+	return n, nil
+}
+
+func (b *pipeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := 0
+	for n < len(p) {
+		if b.rdone {
+			return n, EPIPE
+		}
+		room := pipeBufferCap - len(b.buf)
+		if room <= 0 {
+			b.cond.Wait()
+			continue
+		}
+		chunk := p[n:]
+		if len(chunk) > room {
+			chunk = chunk[:room]
+		}
+		b.buf = append(b.buf, chunk...)
+		n += len(chunk)
+		b.cond.Broadcast()
+	}
+	return n, nil
+}
+
+func (b *pipeBuffer) closeRead() {
+	b.mu.Lock()
+	b.rdone = true
+	b.cond.Broadcast()
+	b.mu.Unlock()
+}
+
+func (b *pipeBuffer) closeWrite() {
+	b.mu.Lock()
+	b.wdone = true
+	b.cond.Broadcast()
+	b.mu.Unlock()
+}
+
+// Op bits reported by ReadWatchEvent. Node's fs.watch only distinguishes
+// "rename" (a name appeared or disappeared in the watched directory) from
+// "change" (an existing file's contents changed), so only WatchWrite and
+// WatchRename are ever produced today; WatchCreate and WatchRemove are
+// reserved so callers like fsnotify can compose the same bitmask Node
+// eventually exposes without another API change.
+const (
+	WatchCreate = 1 << iota
+	WatchWrite
+	WatchRemove
+	WatchRename
+)
+
+type watchEvent struct {
+	name string
+	op   uint32
+}
+
+// watcher backs the fd returned by Watch. Its JS-side fs.watch callback
+// keeps running on the event loop and pushes into events; ReadWatchEvent
+// drains it from Go.
+type watcher struct {
+	jsWatcher js.Value
+	callback  js.Func
+	events    chan watchEvent
+	done      chan struct{}
+}
+
+var watchers = map[int]*watcher{}
+
+// Watch registers a recursive or single-directory watch on path using
+// Node's fs.watch and returns a watcher fd. Events are buffered into a
+// channel by the JS callback and drained one at a time by ReadWatchEvent;
+// Close tears the watcher down.
+func Watch(path string, recursive bool) (int, error) {
+	if err := checkPath(path); err != nil {
+		return 0, err
+	}
+
+	w := &watcher{
+		events: make(chan watchEvent, 64),
+		done:   make(chan struct{}),
+	}
+	w.callback = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 2 {
+			return nil
+		}
+		ev := watchEvent{name: args[1].String(), op: watchOp(args[0].String())}
+		select {
+		case w.events <- ev:
+		default:
+			// caller isn't draining fast enough; drop rather than block
+			// the JS event loop.
+		}
+		return nil
+	})
+
+	options := js.ValueOf(map[string]interface{}{"recursive": recursive})
+	jsWatcher, err := fsCall("watch", path, options, w.callback)
+	if err != nil {
+		w.callback.Release()
+		return 0, err
+	}
+	w.jsWatcher = jsWatcher
+
+	filesMu.Lock()
+	fd := allocFD()
+	watchers[fd] = w
+	filesMu.Unlock()
+	return fd, nil
+}
+
+// watchOp maps a Node fs.watch eventType ("rename" or "change") to the
+// corresponding Watch* bit.
+func watchOp(eventType string) uint32 {
+	switch eventType {
+	case "change":
+		return WatchWrite
+	case "rename":
+		return WatchRename
+	default:
+		return 0
+	}
+}
+
+// ReadWatchEvent blocks until the next change is reported for the watcher
+// fd returned by Watch, or the watcher is closed.
+func ReadWatchEvent(fd int) (name string, op uint32, err error) {
+	filesMu.Lock()
+	w, ok := watchers[fd]
+	filesMu.Unlock()
+	if !ok {
+		return "", 0, EBADF
+	}
+
+	select {
+	case ev := <-w.events:
+		return ev.name, ev.op, nil
+	case <-w.done:
+		return "", 0, EBADF
+	}
+}
+
+func fsCall(name string, args ...interface{}) (js.Value, error) {
+	return jsCall(jsFS, name, args...)
+}
+
+// jsCall invokes a synchronous method on an arbitrary JS object (e.g. an
+// opendirSync Dir handle), converting a thrown exception into a Go error
+// the same way fsCall does for the top-level "fs" object.
+func jsCall(recv js.Value, name string, args ...interface{}) (res js.Value, err error) {
 	defer recoverErr(&err)
-	res = jsFS.Call(name, args...)
+	res = recv.Call(name, args...)
 	return
 }
 
@@ -488,10 +959,17 @@ func recoverErr(errPtr *error) {
 		if !ok {
 			panic(err)
 		}
-		errno, ok := errnoByCode[jsErr.Get("code").String()]
-		if !ok {
-			panic(err)
-		}
-		*errPtr = errnoErr(Errno(errno))
+		*errPtr = errFromJS(jsErr.Value)
+	}
+}
+
+// errFromJS maps a JS Error's code (e.g. "ENOENT") to the matching Errno.
+// It panics with the original value if the code is not recognized, mirroring
+// the previous behavior of recoverErr.
+func errFromJS(jsErr js.Value) error {
+	errno, ok := errnoByCode[jsErr.Get("code").String()]
+	if !ok {
+		panic(js.Error{Value: jsErr})
 	}
+	return errnoErr(Errno(errno))
 }
@@ -0,0 +1,48 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm,!js_fs_sync
+
+package syscall
+
+import "syscall/js"
+
+// fsCallAsync invokes the callback-style fs.<name> function (e.g. "open",
+// "read", "stat") and parks the calling goroutine until the JS callback
+// fires, instead of blocking the event loop the way the *Sync bindings do.
+// name must name the non-Sync variant of the Node fs function; callers pass
+// the same arguments they would to the *Sync call, without the callback.
+func fsCallAsync(name string, args ...interface{}) (res js.Value, err error) {
+	fn := jsFS.Get(name)
+	if fn.Type() != js.TypeFunction {
+		return js.Value{}, ENOSYS
+	}
+
+	done := make(chan struct{})
+	callback := js.FuncOf(func(this js.Value, cbArgs []js.Value) interface{} {
+		if len(cbArgs) > 0 && cbArgs[0].Truthy() {
+			err = errFromJS(cbArgs[0])
+		} else if len(cbArgs) > 1 {
+			res = cbArgs[1]
+		}
+		close(done)
+		return nil
+	})
+	defer callback.Release()
+
+	if invokeErr := invoke(fn, append(args, callback)...); invokeErr != nil {
+		return js.Value{}, invokeErr
+	}
+
+	<-done
+	return res, err
+}
+
+// invoke calls fn and converts a thrown JS exception into a Go error,
+// mirroring recoverErr for the synchronous fsCall path.
+func invoke(fn js.Value, args ...interface{}) (err error) {
+	defer recoverErr(&err)
+	fn.Invoke(args...)
+	return
+}
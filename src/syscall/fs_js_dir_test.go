@@ -0,0 +1,68 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package syscall_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// TestReadDirentPaging exercises the lazy directory listing added to defer
+// readdirSync/opendirSync until the first ReadDirent call, forcing several
+// small reads so fillDirWindow has to page more than once.
+func TestReadDirentPaging(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syscall-fs-js-dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	const n = 8
+	want := map[string]bool{}
+	for i := 0; i < n; i++ {
+		name := filepath.Join(dir, "entry"+string(rune('a'+i)))
+		fd, err := syscall.Open(name, syscall.O_WRONLY|syscall.O_CREAT, 0644)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		syscall.Close(fd)
+		want[filepath.Base(name)] = true
+	}
+
+	fd, err := syscall.Open(dir, syscall.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("Open(dir): %v", err)
+	}
+	defer syscall.Close(fd)
+
+	got := map[string]bool{}
+	buf := make([]byte, 16)
+	for {
+		bn, err := syscall.ReadDirent(fd, buf)
+		if err != nil {
+			t.Fatalf("ReadDirent: %v", err)
+		}
+		if bn == 0 {
+			break
+		}
+		data := buf[:bn]
+		for len(data) > 0 {
+			l := int(data[0]) | int(data[1])<<8
+			got[string(data[2:l])] = true
+			data = data[l:]
+		}
+	}
+
+	for name := range want {
+		if !got[name] {
+			t.Errorf("missing directory entry %q", name)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package syscall
+
+import "testing"
+
+// TestStdFilesRealFD guards against the std fd table entries dispatching
+// through the zero value of realFD (Node fd 0): fd 1 and fd 2 briefly
+// lacked an explicit realFD, so Fstat/Read/Write/etc. on stderr silently
+// hit stdin instead.
+func TestStdFilesRealFD(t *testing.T) {
+	for fd, want := range map[int]int{0: 0, 1: 1, 2: 2} {
+		f, err := fdToFile(fd)
+		if err != nil {
+			t.Fatalf("fdToFile(%d): %v", fd, err)
+		}
+		if f.realFD != want {
+			t.Errorf("files[%d].realFD = %d, want %d", fd, f.realFD, want)
+		}
+	}
+}
+
+// TestDupRefcount exercises Dup/Close bookkeeping on a Pipe, whose fds
+// never touch Node's "fs" object, so the test doesn't depend on a live
+// runtime to back the underlying file.
+func TestDupRefcount(t *testing.T) {
+	var fds [2]int
+	if err := Pipe(fds[:]); err != nil {
+		t.Fatalf("Pipe: %v", err)
+	}
+	rfd, wfd := fds[0], fds[1]
+	defer Close(wfd)
+
+	dupfd, err := Dup(rfd)
+	if err != nil {
+		t.Fatalf("Dup: %v", err)
+	}
+
+	if err := Close(rfd); err != nil {
+		t.Fatalf("Close(rfd): %v", err)
+	}
+
+	// The pipe's read end must still be alive via dupfd: a write followed
+	// by a read through dupfd should succeed even though rfd is closed.
+	go Write(wfd, []byte("x"))
+	buf := make([]byte, 1)
+	if n, err := Read(dupfd, buf); err != nil || n != 1 {
+		t.Fatalf("Read(dupfd) = %d, %v, want 1, nil", n, err)
+	}
+
+	if err := Close(dupfd); err != nil {
+		t.Fatalf("Close(dupfd): %v", err)
+	}
+	if _, err := Read(dupfd, buf); err != EBADF {
+		t.Fatalf("Read after final Close = %v, want EBADF", err)
+	}
+}
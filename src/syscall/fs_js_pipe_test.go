@@ -0,0 +1,47 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package syscall_test
+
+import (
+	"syscall"
+	"testing"
+)
+
+// TestPipeBackpressure writes more than a pipe's buffer can hold in a single
+// Write and checks that, with a concurrent reader draining the other end,
+// every byte arrives and the Write completes without error.
+func TestPipeBackpressure(t *testing.T) {
+	var fds [2]int
+	if err := syscall.Pipe(fds[:]); err != nil {
+		t.Fatalf("Pipe: %v", err)
+	}
+	rfd, wfd := fds[0], fds[1]
+	defer syscall.Close(rfd)
+
+	const total = 200 * 1024 // several times the pipe's capacity
+	data := make([]byte, total)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := syscall.Write(wfd, data)
+		syscall.Close(wfd)
+		done <- err
+	}()
+
+	read := 0
+	buf := make([]byte, 4096)
+	for read < total {
+		n, err := syscall.Read(rfd, buf)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		read += n
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm,js_fs_sync
+
+package syscall
+
+import "syscall/js"
+
+// fsCallAsync falls back to the blocking *Sync bindings for embedders whose
+// "fs" object does not implement Node's callback-style API. Build with the
+// js_fs_sync tag to select this fallback.
+func fsCallAsync(name string, args ...interface{}) (js.Value, error) {
+	return fsCall(name+"Sync", args...)
+}
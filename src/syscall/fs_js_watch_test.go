@@ -0,0 +1,49 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package syscall_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// TestWatch registers a watch on a directory and expects a rename event
+// when a file is created inside it.
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syscall-fs-js-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wfd, err := syscall.Watch(dir, false)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer syscall.Close(wfd)
+
+	path := filepath.Join(dir, "new-file")
+	fd, err := syscall.Open(path, syscall.O_WRONLY|syscall.O_CREAT, 0644)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	syscall.Close(fd)
+
+	name, op, err := syscall.ReadWatchEvent(wfd)
+	if err != nil {
+		t.Fatalf("ReadWatchEvent: %v", err)
+	}
+	if name != "new-file" {
+		t.Errorf("event name = %q, want %q", name, "new-file")
+	}
+	if op&syscall.WatchRename == 0 {
+		t.Errorf("event op = %#x, missing WatchRename", op)
+	}
+}